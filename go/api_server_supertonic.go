@@ -1,14 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,6 +24,7 @@ import (
 	"github.com/go-audio/audio"
 	"github.com/go-audio/wav"
 	ort "github.com/yalue/onnxruntime_go"
+	"golang.org/x/sync/errgroup"
 )
 
 // Server config constants
@@ -28,13 +36,23 @@ const (
 	Speed           = 1.0
 	SilenceDuration = 0.3
 	TTSPoolSize     = 2
+
+	// StreamWriteIdleTimeout bounds how long writing one streamed chunk to
+	// the client may take before we give up on a slow/dead client. Time spent
+	// synthesizing the next chunk is not counted against it.
+	StreamWriteIdleTimeout = 5 * time.Second
+
+	// DefaultTargetLUFS and DefaultTruePeakDBFS are the loudness-normalization
+	// defaults used when neither the request nor the style specify one.
+	// -16 LUFS / -1 dBFS true peak match common broadcast TTS conventions.
+	DefaultTargetLUFS   = -16.0
+	DefaultTruePeakDBFS = -1.0
 )
 
 // Global variables
 var (
-	cfg        Config
-	ttsPool    chan *TextToSpeech        // model doesn't support concurrent inference, so we need to use a pool to manage the models
-	styleCache = make(map[string]*Style) // loaded at init, read-only after
+	cfg     Config
+	ttsPool chan *TextToSpeech // model doesn't support concurrent inference, so we need to use a pool to manage the models
 )
 
 // TTSRequest holds TTS request parameters
@@ -43,6 +61,15 @@ type TTSRequest struct {
 	Text        string  `json:"text" form:"text"`
 	Lang        string  `json:"lang" form:"lang"`
 	VolumeGain  float32 `json:"volume_gain" form:"volume_gain"` // only applies when > 1.0
+	Format      string  `json:"format" form:"format"`           // "ssml" to parse Text as SSML, auto-detected from a leading <speak> tag otherwise
+	AudioFormat string  `json:"audio_format" form:"audio_format"` // output codec name ("wav", "mp3", "opus", "flac"); negotiated from Accept when unset
+
+	// TargetLUFS and TruePeakDBFS configure the BS.1770/EBU R128 loudness
+	// normalization pass. Both are optional (0 means "use the style's
+	// default, or the server default"); they're ignored when VolumeGain
+	// is above 1.0, since that takes the legacy linear-gain path instead.
+	TargetLUFS   float32 `json:"target_lufs" form:"target_lufs"`
+	TruePeakDBFS float32 `json:"true_peak_dbfs" form:"true_peak_dbfs"`
 }
 
 func main() {
@@ -80,10 +107,29 @@ func main() {
 
 	// Preload all voice styles
 	log.Println("Preloading voice styles...")
-	if err := preloadVoiceStyles(); err != nil {
+	if err := voiceRegistry.reload(); err != nil {
 		log.Fatalf("Failed to preload some voice styles: %v", err)
 	}
 
+	// Watch VoiceStyleDir so dropping in a new *.json live-registers it
+	if err := watchVoiceStyleDir(); err != nil {
+		log.Printf("Warning: voice style watcher disabled: %v", err)
+	}
+
+	// The mutating /voices endpoints can overwrite or delete arbitrary style
+	// files, so they must never be left open by default. If the operator
+	// hasn't configured a secret, generate one and print it once rather than
+	// trusting that whoever deploys this reads the startup logs for a
+	// warning.
+	if os.Getenv(AdminSecretEnv) == "" {
+		secret, err := generateAdminSecret()
+		if err != nil {
+			log.Fatalf("Failed to generate %s: %v", AdminSecretEnv, err)
+		}
+		os.Setenv(AdminSecretEnv, secret)
+		log.Printf("%s was not set; generated one for this run. Pass it as the %s header: %s", AdminSecretEnv, AdminSecretHeader, secret)
+	}
+
 	// Setup gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
@@ -93,6 +139,11 @@ func main() {
 	router.GET("/tts", ttsHandler)
 	router.POST("/tts", ttsHandler)
 
+	router.GET("/voices", listVoicesHandler)
+	router.POST("/voices/reload", requireAdminSecret(), reloadVoicesHandler)
+	router.PUT("/voices/:name", requireAdminSecret(), putVoiceHandler)
+	router.DELETE("/voices/:name", requireAdminSecret(), deleteVoiceHandler)
+
 	// Start server
 	addr := fmt.Sprintf("0.0.0.0:%d", Port)
 	log.Printf("Starting server, listening on %s", addr)
@@ -129,39 +180,6 @@ func main() {
 	log.Println("Server exited")
 }
 
-// preloadVoiceStyles loads all voice styles from directory into cache
-func preloadVoiceStyles() error {
-	files, err := os.ReadDir(VoiceStyleDir)
-	if err != nil {
-		return fmt.Errorf("failed to read voice style directory: %w", err)
-	}
-
-	for _, file := range files {
-		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
-			continue
-		}
-		speakerName := file.Name()[:len(file.Name())-5] // remove .json
-		voiceStylePath := filepath.Join(VoiceStyleDir, file.Name())
-		style, err := LoadVoiceStyle([]string{voiceStylePath}, false)
-		if err != nil {
-			log.Printf("Warning: failed to load voice style %s: %v", speakerName, err)
-			continue
-		}
-		styleCache[speakerName] = style
-	}
-
-	log.Printf("Loaded %d voice styles into cache", len(styleCache))
-	return nil
-}
-
-// getVoiceStyle gets voice style from cache
-func getVoiceStyle(speakerName string) (*Style, error) {
-	if style, ok := styleCache[speakerName]; ok {
-		return style, nil
-	}
-	return nil, fmt.Errorf("voice style not found: %s", speakerName)
-}
-
 func homeHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
@@ -189,21 +207,33 @@ func ttsHandler(c *gin.Context) {
 		req.SpeakerName, req.Lang, len(req.Text), req.VolumeGain)
 
 	// Get voice style from cache
-	style, err := getVoiceStyle(req.SpeakerName)
+	style, err := voiceRegistry.get(req.SpeakerName)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid speaker_name: %s (%v)", req.SpeakerName, err)})
 		return
 	}
 
-	// Get TTS instance from pool
-	tts := <-ttsPool
+	if isStreamRequest(c) {
+		streamTTSHandler(c, req, style)
+		return
+	}
+
+	var wavData []float32
+	var duration float64
+	var sampleRate int
+
 	start := time.Now()
-	wavData, duration, err := tts.Call(req.Text, req.Lang, style, TotalStep, Speed, SilenceDuration)
-	if req.VolumeGain > 1.0 {
-		wavData = applyGain(wavData, req.VolumeGain)
+	if isSSML(&req) {
+		wavData, duration, sampleRate, err = synthesizeSSML(c.Request.Context(), req.Text, req.Lang, style)
+	} else {
+		// Get TTS instance from pool
+		tts := <-ttsPool
+		wavData, duration, err = tts.Call(req.Text, req.Lang, style, TotalStep, Speed, SilenceDuration)
+		sampleRate = tts.SampleRate
+		ttsPool <- tts // Return to pool
 	}
+	wavData = applyLoudness(wavData, sampleRate, req)
 	elapsed := time.Since(start)
-	ttsPool <- tts // Return to pool
 
 	if err != nil {
 		log.Printf("TTS failed: %v", err)
@@ -218,16 +248,172 @@ func ttsHandler(c *gin.Context) {
 	log.Printf("TTS succeeded, speaker=%s, lang=%s, text_size=%d, duration=%.2fs, elapsed=%.2fs, rtf=%.2f",
 		req.SpeakerName, req.Lang, len(req.Text), duration, elapsed.Seconds(), rtf)
 
-	// Write WAV directly to response
-	wavByts, err := encodeWav(wavData, tts.SampleRate)
-	if err != nil {
-		log.Printf("Failed to encode WAV: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to encode WAV: %v", err)})
+	// Encode to the requested (or negotiated) audio codec
+	encName, encoder := resolveAudioEncoder(req.AudioFormat, c.GetHeader("Accept"))
+	var buf bytes.Buffer
+	if err := encoder.Encode(wavData, sampleRate, &buf); err != nil {
+		log.Printf("Failed to encode %s: %v", encName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to encode %s: %v", encName, err)})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="output.%s"`, encoder.Extension()))
+	c.Data(http.StatusOK, encoder.ContentType(), buf.Bytes())
+}
+
+// isStreamRequest reports whether the client asked for a chunked streaming
+// response instead of a fully-buffered one.
+func isStreamRequest(c *gin.Context) bool {
+	if c.Query("stream") == "1" || c.PostForm("stream") == "1" {
+		return true
+	}
+	accept := c.GetHeader("Accept")
+	return strings.Contains(accept, "audio/wav") && strings.Contains(accept, "codecs=1")
+}
+
+// streamTTSHandler synthesizes req.Text one sentence at a time and flushes
+// each sentence's PCM frames to the client as soon as it's ready, instead of
+// buffering the whole utterance via encodeWav. It writes a WAV header with
+// unknown (0xFFFFFFFF) chunk sizes up front since the final length isn't
+// known until the last sentence finishes.
+func streamTTSHandler(c *gin.Context, req TTSRequest, style *Style) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming not supported by response writer"})
+		return
+	}
+
+	var segments []ttsSegment
+	if isSSML(&req) {
+		var err error
+		segments, err = parseSSML(req.Text)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid SSML: %v", err)})
+			return
+		}
+	} else {
+		for _, sentence := range splitSentences(req.Text) {
+			segments = append(segments, ttsSegment{text: sentence, rate: 1.0})
+		}
+	}
+	if len(segments) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no text to synthesize"})
 		return
 	}
 
 	c.Header("Content-Disposition", `attachment; filename="output.wav"`)
-	c.Data(http.StatusOK, "audio/wav", wavByts)
+	c.Writer.Header().Set("Content-Type", "audio/wav")
+	c.Writer.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(c.Writer)
+	start := time.Now()
+	headerWritten := false
+	var totalDuration float64
+	var sampleRate int
+
+	for i, seg := range segments {
+		var samples []float32
+
+		if seg.isBreak {
+			if sampleRate == 0 {
+				sampleRate = peekSampleRate()
+			}
+			n := int(seg.duration * float32(sampleRate))
+			if n < 0 {
+				n = 0
+			}
+			samples = make([]float32, n)
+		} else {
+			tts := <-ttsPool
+			var duration float64
+			var err error
+			samples, duration, err = tts.Call(seg.text, req.Lang, style, TotalStep, Speed*seg.rate, SilenceDuration)
+			sampleRate = tts.SampleRate
+			ttsPool <- tts
+			if err != nil {
+				log.Printf("Streaming TTS failed on segment %d: %v", i, err)
+				return
+			}
+			totalDuration += duration
+		}
+
+		if !seg.isBreak {
+			samples = applyLoudness(samples, sampleRate, req)
+		}
+
+		if !headerWritten {
+			if err := writeWavStreamHeader(c.Writer, sampleRate, 1, 16); err != nil {
+				log.Printf("Failed to write streaming WAV header: %v", err)
+				return
+			}
+			headerWritten = true
+		}
+
+		if err := rc.SetWriteDeadline(time.Now().Add(StreamWriteIdleTimeout)); err != nil {
+			log.Printf("Streaming response writer doesn't support write deadlines: %v", err)
+		}
+		if err := writePCMFrames(c.Writer, samples); err != nil {
+			log.Printf("Streaming client disconnected: %v", err)
+			return
+		}
+		flusher.Flush()
+	}
+
+	elapsed := time.Since(start)
+	var rtf float32
+	if totalDuration > 0 {
+		rtf = float32(elapsed.Seconds()) / totalDuration
+	}
+	log.Printf("Streaming TTS succeeded, speaker=%s, lang=%s, text_size=%d, duration=%.2fs, elapsed=%.2fs, rtf=%.2f",
+		req.SpeakerName, req.Lang, len(req.Text), totalDuration, elapsed.Seconds(), rtf)
+}
+
+// peekSampleRate borrows a TTS instance just to read its sample rate,
+// needed when a stream's first segment is an SSML <break> and there's no
+// synthesized audio yet to infer it from.
+func peekSampleRate() int {
+	tts := <-ttsPool
+	sr := tts.SampleRate
+	ttsPool <- tts
+	return sr
+}
+
+// writeWavStreamHeader writes a 44-byte canonical WAV header with the RIFF
+// and data chunk sizes set to 0xFFFFFFFF, since the total length isn't known
+// until streaming finishes. Most players treat this as "stream until EOF".
+func writeWavStreamHeader(w io.Writer, sampleRate, numChannels, bitsPerSample int) error {
+	byteRate := sampleRate * numChannels * bitsPerSample / 8
+	blockAlign := numChannels * bitsPerSample / 8
+
+	header := make([]byte, 44)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], 0xFFFFFFFF)
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], 1) // PCM
+	binary.LittleEndian.PutUint16(header[22:24], uint16(numChannels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:36], uint16(bitsPerSample))
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], 0xFFFFFFFF)
+
+	_, err := w.Write(header)
+	return err
+}
+
+// writePCMFrames converts float32 samples in [-1, 1] to 16-bit little-endian
+// PCM and writes them to w.
+func writePCMFrames(w io.Writer, samples []float32) error {
+	buf := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		clamped := math.Max(-1.0, math.Min(1.0, float64(s)))
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(int16(clamped*32767)))
+	}
+	_, err := w.Write(buf)
+	return err
 }
 
 // memWriteSeeker is an in-memory io.WriteSeeker
@@ -312,3 +498,274 @@ func applyGain(samples []float32, gain float32) []float32 {
 	}
 	return samples
 }
+
+// applyLoudness picks the legacy linear-gain path or BS.1770 loudness
+// normalization for samples, exactly like the buffered /tts response does,
+// so streaming responses get the same target_lufs/true_peak_dbfs default
+// handling instead of silently ignoring them. The style-level default comes
+// from voiceRegistry rather than the Style struct itself, since per-speaker
+// loudness leveling is a registry concern, not something LoadVoiceStyle
+// produces.
+func applyLoudness(samples []float32, sampleRate int, req TTSRequest) []float32 {
+	if req.VolumeGain > 1.0 {
+		return applyGain(samples, req.VolumeGain)
+	}
+
+	targetLUFS := req.TargetLUFS
+	if targetLUFS == 0 {
+		if styleDefault := voiceRegistry.targetLUFS(req.SpeakerName); styleDefault != 0 {
+			targetLUFS = styleDefault
+		} else {
+			targetLUFS = DefaultTargetLUFS
+		}
+	}
+	truePeakDBFS := req.TruePeakDBFS
+	if truePeakDBFS == 0 {
+		truePeakDBFS = DefaultTruePeakDBFS
+	}
+	return normalizeLoudness(samples, sampleRate, targetLUFS, truePeakDBFS)
+}
+
+// ttsSegment is one unit of SSML work: either a sentence to synthesize or a
+// fixed-duration silence gap from a <break> tag.
+type ttsSegment struct {
+	isBreak  bool
+	text     string
+	rate     float32 // <prosody rate=...> multiplier in effect, 1.0 if unspecified
+	duration float32 // break duration in seconds, only valid when isBreak
+}
+
+var sentenceBoundary = regexp.MustCompile(`(?s)([^.!?]*[.!?]+)\s*`)
+
+// splitSentences splits text into utterance-sized parts on sentence
+// boundaries, falling back to the whole (trimmed) text if no boundary is found.
+func splitSentences(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	var out []string
+	consumed := 0
+	for _, m := range sentenceBoundary.FindAllStringSubmatch(text, -1) {
+		if s := strings.TrimSpace(m[1]); s != "" {
+			out = append(out, s)
+		}
+		consumed += len(m[0])
+	}
+	if rest := strings.TrimSpace(text[consumed:]); rest != "" {
+		out = append(out, rest)
+	}
+	if len(out) == 0 {
+		out = append(out, text)
+	}
+	return out
+}
+
+// Bounds for client-controlled SSML values: a <prosody rate> outside
+// [minProsodyRate, maxProsodyRate] is clamped before it reaches tts.Call,
+// and a <break time> longer than maxBreakDuration is clamped before it's
+// turned into a silence buffer, so a single request can't demand an
+// unreasonable allocation or an unreasonable synthesis speed.
+const (
+	minProsodyRate   = 0.25
+	maxProsodyRate   = 4.0
+	maxBreakDuration = 120.0 // seconds
+)
+
+func clampProsodyRate(rate float32) float32 {
+	if rate < minProsodyRate {
+		return minProsodyRate
+	}
+	if rate > maxProsodyRate {
+		return maxProsodyRate
+	}
+	return rate
+}
+
+// parseProsodyRate parses an SSML <prosody rate="..."> value: a bare
+// multiplier ("1.5"), a percentage ("150%"), or one of the named rates.
+// The result is clamped to [minProsodyRate, maxProsodyRate].
+func parseProsodyRate(v string) float32 {
+	v = strings.TrimSpace(v)
+	switch v {
+	case "":
+		return 1.0
+	case "x-slow":
+		return 0.5
+	case "slow":
+		return 0.75
+	case "medium":
+		return 1.0
+	case "fast":
+		return 1.25
+	case "x-fast":
+		return 1.5
+	}
+	if strings.HasSuffix(v, "%") {
+		if n, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 32); err == nil {
+			return clampProsodyRate(float32(n) / 100.0)
+		}
+		return 1.0
+	}
+	if n, err := strconv.ParseFloat(v, 32); err == nil {
+		return clampProsodyRate(float32(n))
+	}
+	return 1.0
+}
+
+// parseBreakTime parses an SSML <break time="..."> value ("500ms" or "2s")
+// into seconds, defaulting to the server's SilenceDuration when unparseable
+// and clamping to maxBreakDuration so a client can't force a multi-gigabyte
+// silence allocation with something like <break time="999999999s">.
+func parseBreakTime(v string) float32 {
+	v = strings.TrimSpace(v)
+	switch {
+	case strings.HasSuffix(v, "ms"):
+		if n, err := strconv.ParseFloat(strings.TrimSuffix(v, "ms"), 32); err == nil {
+			return clampBreakDuration(float32(n) / 1000.0)
+		}
+	case strings.HasSuffix(v, "s"):
+		if n, err := strconv.ParseFloat(strings.TrimSuffix(v, "s"), 32); err == nil {
+			return clampBreakDuration(float32(n))
+		}
+	}
+	return SilenceDuration
+}
+
+func clampBreakDuration(d float32) float32 {
+	if d < 0 {
+		return 0
+	}
+	if d > maxBreakDuration {
+		return maxBreakDuration
+	}
+	return d
+}
+
+// isSSML reports whether the request text should be parsed as SSML, either
+// because the client set format=ssml or the text itself starts with <speak>.
+func isSSML(req *TTSRequest) bool {
+	if strings.EqualFold(req.Format, "ssml") {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(req.Text), "<speak")
+}
+
+// parseSSML walks an SSML document and flattens it into a sequence of
+// sentence-sized synthesis segments and break-tag silences, tracking
+// <prosody rate=...> spans as it goes.
+func parseSSML(raw string) ([]ttsSegment, error) {
+	decoder := xml.NewDecoder(strings.NewReader(raw))
+	rateStack := []float32{1.0}
+	var segments []ttsSegment
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSML: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "prosody":
+				rate := rateStack[len(rateStack)-1]
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "rate" {
+						rate = parseProsodyRate(attr.Value)
+					}
+				}
+				rateStack = append(rateStack, rate)
+			case "break":
+				dur := float32(SilenceDuration)
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "time" {
+						dur = parseBreakTime(attr.Value)
+					}
+				}
+				segments = append(segments, ttsSegment{isBreak: true, duration: dur})
+			}
+		case xml.EndElement:
+			if t.Name.Local == "prosody" && len(rateStack) > 1 {
+				rateStack = rateStack[:len(rateStack)-1]
+			}
+		case xml.CharData:
+			rate := rateStack[len(rateStack)-1]
+			for _, sentence := range splitSentences(string(t)) {
+				segments = append(segments, ttsSegment{text: sentence, rate: rate})
+			}
+		}
+	}
+
+	return segments, nil
+}
+
+// synthesizeSSML parses SSML text into segments and synthesizes the
+// sentence segments concurrently, bounded by the size of ttsPool, then
+// stitches the resulting float32 buffers back together in document order
+// with silence inserted for each <break>.
+func synthesizeSSML(ctx context.Context, text string, lang string, style *Style) ([]float32, float64, int, error) {
+	segments, err := parseSSML(text)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	results := make([][]float32, len(segments))
+	var mu sync.Mutex
+	var sampleRate int
+	var totalDuration float64
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, seg := range segments {
+		if seg.isBreak {
+			continue
+		}
+		i, seg := i, seg
+		g.Go(func() error {
+			select {
+			case <-gctx.Done():
+				return gctx.Err()
+			default:
+			}
+			tts := <-ttsPool
+			defer func() { ttsPool <- tts }()
+
+			speed := Speed * seg.rate
+			samples, duration, err := tts.Call(seg.text, lang, style, TotalStep, speed, SilenceDuration)
+			if err != nil {
+				return fmt.Errorf("ssml segment %d: %w", i, err)
+			}
+
+			mu.Lock()
+			sampleRate = tts.SampleRate
+			totalDuration += duration
+			mu.Unlock()
+
+			results[i] = samples
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Breaks need the sample rate discovered above, so fill them in afterward.
+	for i, seg := range segments {
+		if seg.isBreak {
+			n := int(seg.duration * float32(sampleRate))
+			if n < 0 {
+				n = 0
+			}
+			results[i] = make([]float32, n)
+		}
+	}
+
+	var out []float32
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, totalDuration, sampleRate, nil
+}
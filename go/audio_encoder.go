@@ -0,0 +1,70 @@
+package main
+
+import (
+	"io"
+	"strings"
+)
+
+// AudioEncoder encodes a mono float32 PCM buffer into a specific container
+// format and reports the HTTP metadata needed to serve it.
+type AudioEncoder interface {
+	Encode(samples []float32, sampleRate int, w io.Writer) error
+	ContentType() string
+	Extension() string
+}
+
+// audioEncoders holds every registered encoder, keyed by the lowercase
+// format name clients pass in TTSRequest.AudioFormat or negotiate via Accept.
+var audioEncoders = map[string]AudioEncoder{}
+
+// registerAudioEncoder makes an encoder selectable by name. Codec-specific
+// files call this from init() so builds without the matching native
+// dependency (see the cgo build tags on the mp3/opus/flac files) simply
+// don't register that codec instead of failing to compile.
+func registerAudioEncoder(name string, enc AudioEncoder) {
+	audioEncoders[name] = enc
+}
+
+func init() {
+	registerAudioEncoder("wav", wavEncoder{})
+}
+
+// resolveAudioEncoder picks an encoder from an explicit format name first,
+// falling back to Accept-header negotiation, and finally to WAV.
+func resolveAudioEncoder(format string, accept string) (string, AudioEncoder) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if enc, ok := audioEncoders[format]; ok {
+		return format, enc
+	}
+
+	for name, mime := range map[string]string{
+		"mp3":  "audio/mpeg",
+		"opus": "audio/opus",
+		"flac": "audio/flac",
+		"wav":  "audio/wav",
+	} {
+		if strings.Contains(accept, mime) {
+			if enc, ok := audioEncoders[name]; ok {
+				return name, enc
+			}
+		}
+	}
+
+	return "wav", audioEncoders["wav"]
+}
+
+// wavEncoder is the default, always-available codec; it just wraps the
+// existing encodeWav helper.
+type wavEncoder struct{}
+
+func (wavEncoder) Encode(samples []float32, sampleRate int, w io.Writer) error {
+	data, err := encodeWav(samples, sampleRate)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (wavEncoder) ContentType() string { return "audio/wav" }
+func (wavEncoder) Extension() string   { return "wav" }
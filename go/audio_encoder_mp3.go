@@ -0,0 +1,42 @@
+//go:build cgo && lame
+
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/viert/lame"
+)
+
+// mp3Encoder shells out to libmp3lame via cgo. It's behind a build tag
+// because not every build environment has the LAME headers available.
+type mp3Encoder struct{}
+
+func init() {
+	registerAudioEncoder("mp3", mp3Encoder{})
+}
+
+func (mp3Encoder) Encode(samples []float32, sampleRate int, w io.Writer) error {
+	enc, err := lame.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	enc.SampleRate = sampleRate
+	enc.NumChannels = 1
+	enc.Quality = 2
+	defer enc.Close()
+
+	pcm := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		clamped := math.Max(-1.0, math.Min(1.0, float64(s)))
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(int16(clamped*32767)))
+	}
+
+	_, err = enc.Write(pcm)
+	return err
+}
+
+func (mp3Encoder) ContentType() string { return "audio/mpeg" }
+func (mp3Encoder) Extension() string   { return "mp3" }
@@ -0,0 +1,172 @@
+//go:build cgo && opus
+
+package main
+
+import (
+	"encoding/binary"
+	"io"
+
+	"gopkg.in/hraban/opus.v2"
+)
+
+// opusEncoder wraps libopus (via cgo) and wraps its packets in a minimal
+// Ogg container so standard players can open the response as a normal
+// .opus file.
+type opusEncoder struct{}
+
+func init() {
+	registerAudioEncoder("opus", opusEncoder{})
+}
+
+const opusFrameMillis = 20
+
+func (opusEncoder) Encode(samples []float32, sampleRate int, w io.Writer) error {
+	enc, err := opus.NewEncoder(sampleRate, 1, opus.AppAudio)
+	if err != nil {
+		return err
+	}
+
+	page := newOggPageWriter(w, 0x4f707573) // "Opus" as a fixed stream serial
+	if err := page.writePacket(opusHeadPacket(sampleRate), 0, true, false); err != nil {
+		return err
+	}
+	if err := page.writePacket(opusTagsPacket(), 0, false, false); err != nil {
+		return err
+	}
+
+	frameSize := sampleRate * opusFrameMillis / 1000
+	granuleStep := uint64(frameSize) * 48000 / uint64(sampleRate)
+	var granule uint64
+
+	packetBuf := make([]byte, 4000)
+	for start := 0; start < len(samples); start += frameSize {
+		end := start + frameSize
+		var frame []float32
+		if end <= len(samples) {
+			frame = samples[start:end]
+		} else {
+			frame = make([]float32, frameSize)
+			copy(frame, samples[start:])
+		}
+
+		n, err := enc.EncodeFloat32(frame, packetBuf)
+		if err != nil {
+			return err
+		}
+		granule += granuleStep
+		last := end >= len(samples)
+		if err := page.writePacket(packetBuf[:n], granule, false, last); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (opusEncoder) ContentType() string { return "audio/opus" }
+func (opusEncoder) Extension() string   { return "opus" }
+
+func opusHeadPacket(sampleRate int) []byte {
+	buf := make([]byte, 19)
+	copy(buf[0:8], "OpusHead")
+	buf[8] = 1 // version
+	buf[9] = 1 // channels
+	binary.LittleEndian.PutUint16(buf[10:12], 0)
+	binary.LittleEndian.PutUint32(buf[12:16], uint32(sampleRate))
+	binary.LittleEndian.PutUint16(buf[16:18], 0) // output gain
+	buf[18] = 0                                  // channel mapping family
+	return buf
+}
+
+func opusTagsPacket() []byte {
+	vendor := "supertonic"
+	buf := make([]byte, 0, 8+4+len(vendor)+4)
+	buf = append(buf, "OpusTags"...)
+	vendorLen := make([]byte, 4)
+	binary.LittleEndian.PutUint32(vendorLen, uint32(len(vendor)))
+	buf = append(buf, vendorLen...)
+	buf = append(buf, vendor...)
+	buf = append(buf, 0, 0, 0, 0) // zero user comments
+	return buf
+}
+
+// oggPageWriter writes packets as single-packet Ogg pages, which is the
+// simplest valid encoding for a stream like ours with no need to split or
+// merge packets across pages.
+type oggPageWriter struct {
+	w       io.Writer
+	serial  uint32
+	pageSeq uint32
+}
+
+func newOggPageWriter(w io.Writer, serial uint32) *oggPageWriter {
+	return &oggPageWriter{w: w, serial: serial}
+}
+
+func (o *oggPageWriter) writePacket(packet []byte, granulePos uint64, first, last bool) error {
+	segments := oggLacingSegments(len(packet))
+
+	header := make([]byte, 0, 27+len(segments)+len(packet))
+	header = append(header, "OggS"...)
+	header = append(header, 0) // stream structure version
+
+	var flags byte
+	if first {
+		flags |= 0x02
+	}
+	if last {
+		flags |= 0x04
+	}
+	header = append(header, flags)
+
+	var granuleBuf, serialBuf, seqBuf [8]byte
+	binary.LittleEndian.PutUint64(granuleBuf[:], granulePos)
+	header = append(header, granuleBuf[:]...)
+	binary.LittleEndian.PutUint32(serialBuf[:4], o.serial)
+	header = append(header, serialBuf[:4]...)
+	binary.LittleEndian.PutUint32(seqBuf[:4], o.pageSeq)
+	header = append(header, seqBuf[:4]...)
+	header = append(header, 0, 0, 0, 0) // checksum placeholder, filled below
+	header = append(header, byte(len(segments)))
+	header = append(header, segments...)
+
+	page := append(header, packet...)
+	binary.LittleEndian.PutUint32(page[22:26], oggCRC32(page))
+
+	o.pageSeq++
+	_, err := o.w.Write(page)
+	return err
+}
+
+func oggLacingSegments(n int) []byte {
+	var segs []byte
+	for n >= 255 {
+		segs = append(segs, 255)
+		n -= 255
+	}
+	return append(segs, byte(n))
+}
+
+var oggCRCTable = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = (crc << 1) ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+func oggCRC32(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc = (crc << 8) ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestResolveAudioEncoder_ExplicitFormat(t *testing.T) {
+	name, enc := resolveAudioEncoder("wav", "")
+	if name != "wav" {
+		t.Errorf("name = %q, want wav", name)
+	}
+	if enc.ContentType() != "audio/wav" {
+		t.Errorf("ContentType() = %q, want audio/wav", enc.ContentType())
+	}
+}
+
+func TestResolveAudioEncoder_ExplicitFormatIsCaseInsensitiveAndTrimmed(t *testing.T) {
+	name, enc := resolveAudioEncoder("  WAV  ", "")
+	if name != "wav" || enc == nil {
+		t.Errorf("resolveAudioEncoder(\"  WAV  \", \"\") = (%q, %v), want (wav, non-nil)", name, enc)
+	}
+}
+
+func TestResolveAudioEncoder_UnknownFormatFallsBackToAccept(t *testing.T) {
+	name, enc := resolveAudioEncoder("nope", "audio/wav")
+	if name != "wav" || enc == nil {
+		t.Errorf("resolveAudioEncoder(\"nope\", \"audio/wav\") = (%q, %v), want (wav, non-nil)", name, enc)
+	}
+}
+
+func TestResolveAudioEncoder_UnregisteredAcceptFallsBackToWav(t *testing.T) {
+	// mp3/opus/flac aren't registered unless their cgo build tag is active,
+	// so negotiating for one without it registered should still land on wav.
+	name, enc := resolveAudioEncoder("", "audio/mpeg")
+	if name != "wav" {
+		t.Errorf("name = %q, want wav", name)
+	}
+	if enc == nil {
+		t.Fatal("expected a non-nil fallback encoder")
+	}
+}
+
+func TestResolveAudioEncoder_NoFormatOrAcceptDefaultsToWav(t *testing.T) {
+	name, enc := resolveAudioEncoder("", "")
+	if name != "wav" || enc == nil {
+		t.Errorf("resolveAudioEncoder(\"\", \"\") = (%q, %v), want (wav, non-nil)", name, enc)
+	}
+}
+
+func TestWavEncoder_ContentTypeAndExtension(t *testing.T) {
+	var enc wavEncoder
+	if enc.ContentType() != "audio/wav" {
+		t.Errorf("ContentType() = %q, want audio/wav", enc.ContentType())
+	}
+	if enc.Extension() != "wav" {
+		t.Errorf("Extension() = %q, want wav", enc.Extension())
+	}
+}
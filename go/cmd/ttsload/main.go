@@ -0,0 +1,354 @@
+// Command ttsload replays a scripted sequence of /tts requests against a
+// running server and reports latency, RTF, TTFB and error-rate stats.
+//
+// Script files contain one request per line:
+//
+//	@0.5s speaker=af_sky lang=en text="Hello, how are you today?"
+//
+// The leading "@<duration>" is the offset from the start of the run at
+// which the request fires; the rest of the line is "key=value" fields sent
+// as the /tts form body ("speaker" is sent as /tts's "speaker_name"). A long single-speaker script with sparse offsets
+// stresses the ttsPool saturation path (a "monologue" run); a script with
+// many closely-spaced offsets exercises queueing under overlap ("crosstalk").
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type scriptLine struct {
+	Offset time.Duration
+	Fields map[string]string
+}
+
+// parseScript reads a load-test script file into time-ordered lines.
+func parseScript(path string) ([]scriptLine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []scriptLine
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		if !strings.HasPrefix(raw, "@") {
+			return nil, fmt.Errorf("line %d: expected line to start with '@<offset>': %q", lineNo, raw)
+		}
+		sp := strings.IndexByte(raw, ' ')
+		if sp < 0 {
+			return nil, fmt.Errorf("line %d: missing fields after offset", lineNo)
+		}
+		offset, err := time.ParseDuration(raw[1:sp])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid offset: %w", lineNo, err)
+		}
+		fields, err := parseFields(raw[sp+1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		lines = append(lines, scriptLine{Offset: offset, Fields: fields})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Offset < lines[j].Offset })
+	return lines, nil
+}
+
+// parseFields parses `key=value key2="quoted value"` into a map, supporting
+// backslash-escaped characters inside quoted values.
+func parseFields(s string) (map[string]string, error) {
+	fields := map[string]string{}
+	i := 0
+	for i < len(s) {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("expected key=value near %q", s[i:])
+		}
+		key := s[i : i+eq]
+		i += eq + 1
+
+		if i < len(s) && s[i] == '"' {
+			i++
+			var val strings.Builder
+			for i < len(s) && s[i] != '"' {
+				if s[i] == '\\' && i+1 < len(s) {
+					val.WriteByte(s[i+1])
+					i += 2
+					continue
+				}
+				val.WriteByte(s[i])
+				i++
+			}
+			fields[key] = val.String()
+			i++ // skip closing quote
+		} else {
+			start := i
+			for i < len(s) && s[i] != ' ' {
+				i++
+			}
+			fields[key] = s[start:i]
+		}
+	}
+	return fields, nil
+}
+
+// applyProfile reshapes a script's schedule according to the chosen ramp
+// profile. "steady" leaves offsets as authored, "burst" fires everything at
+// once, and "ramp" keeps the same overall run length but maps each line's
+// fractional position f in it to 1-(1-f)^2, squeezing requests that were
+// originally spread evenly into an increasingly dense tail — request density
+// actually climbs over the run instead of the schedule just playing out slower.
+func applyProfile(lines []scriptLine, profile string) []scriptLine {
+	switch profile {
+	case "burst":
+		for i := range lines {
+			lines[i].Offset = 0
+		}
+	case "ramp":
+		n := len(lines)
+		if n > 1 {
+			total := float64(lines[n-1].Offset)
+			for i := range lines {
+				rem := 1 - float64(i)/float64(n-1)
+				lines[i].Offset = time.Duration(total * (1 - rem*rem))
+			}
+		}
+	}
+	return lines
+}
+
+type result struct {
+	Offset      time.Duration `json:"offset"`
+	Speaker     string        `json:"speaker"`
+	Lang        string        `json:"lang"`
+	Status      int           `json:"status"`
+	LatencyMs   int64         `json:"latency_ms"`
+	TTFBMs      int64         `json:"ttfb_ms"`
+	AudioDurSec float64       `json:"audio_duration_s"`
+	RTF         float64       `json:"rtf"`
+	Err         string        `json:"error,omitempty"`
+}
+
+type report struct {
+	Total   int      `json:"total"`
+	Errors  int      `json:"errors"`
+	Results []result `json:"results"`
+}
+
+// fireRequest sends one scripted request and measures latency, TTFB, and
+// (by parsing the returned WAV) RTF.
+func fireRequest(client *http.Client, target string, line scriptLine) result {
+	res := result{Offset: line.Offset, Speaker: line.Fields["speaker"], Lang: line.Fields["lang"]}
+
+	form := url.Values{}
+	for k, v := range line.Fields {
+		if k == "speaker" {
+			k = "speaker_name"
+		}
+		form.Set(k, v)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(target, "/")+"/tts", strings.NewReader(form.Encode()))
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var ttfb time.Time
+	reqStart := time.Now()
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { ttfb = time.Now() },
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		res.Err = err.Error()
+		res.LatencyMs = time.Since(reqStart).Milliseconds()
+		return res
+	}
+	defer resp.Body.Close()
+
+	body, readErr := io.ReadAll(resp.Body)
+	res.LatencyMs = time.Since(reqStart).Milliseconds()
+	res.Status = resp.StatusCode
+	if !ttfb.IsZero() {
+		res.TTFBMs = ttfb.Sub(reqStart).Milliseconds()
+	}
+	if readErr != nil {
+		res.Err = readErr.Error()
+		return res
+	}
+	if resp.StatusCode != http.StatusOK {
+		res.Err = fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return res
+	}
+
+	if sampleRate, numSamples, ok := parseWavAudioLen(body); ok && sampleRate > 0 {
+		res.AudioDurSec = float64(numSamples) / float64(sampleRate)
+		if res.AudioDurSec > 0 {
+			res.RTF = float64(res.LatencyMs) / 1000 / res.AudioDurSec
+		}
+	}
+	return res
+}
+
+// parseWavAudioLen scans a WAV byte stream for its "data" subchunk and
+// returns the sample rate and sample count. The "fmt " fields are read at
+// their canonical offsets, but the "data" subchunk is found by walking the
+// chunk list, so extra chunks between "fmt " and "data" are tolerated.
+func parseWavAudioLen(b []byte) (sampleRate int, numSamples int, ok bool) {
+	if len(b) < 44 || string(b[0:4]) != "RIFF" || string(b[8:12]) != "WAVE" {
+		return 0, 0, false
+	}
+	channels := int(binary.LittleEndian.Uint16(b[22:24]))
+	sampleRate = int(binary.LittleEndian.Uint32(b[24:28]))
+	bitsPerSample := int(binary.LittleEndian.Uint16(b[34:36]))
+	if channels == 0 || bitsPerSample == 0 {
+		return 0, 0, false
+	}
+
+	for i := 12; i+8 <= len(b); {
+		id := string(b[i : i+4])
+		size := int(binary.LittleEndian.Uint32(b[i+4 : i+8]))
+		if id == "data" {
+			n := size
+			if size <= 0 || i+8+size > len(b) {
+				n = len(b) - (i + 8)
+			}
+			return sampleRate, n / (bitsPerSample / 8 * channels), true
+		}
+		i += 8 + size
+		if size%2 == 1 { // subchunks are word-aligned
+			i++
+		}
+	}
+	return 0, 0, false
+}
+
+func buildReport(results []result) report {
+	rep := report{Total: len(results), Results: results}
+	for _, r := range results {
+		if r.Err != "" || r.Status != http.StatusOK {
+			rep.Errors++
+		}
+	}
+	return rep
+}
+
+func writeReport(rep report, format, outPath string) error {
+	w := io.Writer(os.Stdout)
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"offset", "speaker", "lang", "status", "latency_ms", "ttfb_ms", "audio_duration_s", "rtf", "error"})
+		for _, r := range rep.Results {
+			cw.Write([]string{
+				r.Offset.String(),
+				r.Speaker,
+				r.Lang,
+				strconv.Itoa(r.Status),
+				strconv.FormatInt(r.LatencyMs, 10),
+				strconv.FormatInt(r.TTFBMs, 10),
+				strconv.FormatFloat(r.AudioDurSec, 'f', 3, 64),
+				strconv.FormatFloat(r.RTF, 'f', 3, 64),
+				r.Err,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+}
+
+func main() {
+	target := flag.String("target", "http://127.0.0.1:8000", "base URL of the TTS server")
+	scriptPath := flag.String("script", "", "path to the load-test script file (required)")
+	concurrency := flag.Int("concurrency", 8, "max concurrent in-flight requests")
+	profile := flag.String("profile", "steady", "schedule profile: steady|burst|ramp")
+	format := flag.String("report", "json", "report format: json|csv")
+	out := flag.String("out", "", "write report to this file instead of stdout")
+	flag.Parse()
+
+	if *scriptPath == "" {
+		fmt.Fprintln(os.Stderr, "ttsload: -script is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	lines, err := parseScript(*scriptPath)
+	if err != nil {
+		log.Fatalf("failed to parse script: %v", err)
+	}
+	lines = applyProfile(lines, *profile)
+
+	client := &http.Client{Timeout: 600 * time.Second}
+	sem := make(chan struct{}, *concurrency)
+	results := make([]result, len(lines))
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i, line := range lines {
+		if wait := time.Until(start.Add(line.Offset)); wait > 0 {
+			time.Sleep(wait)
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, line scriptLine) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fireRequest(client, *target, line)
+		}(i, line)
+	}
+	wg.Wait()
+
+	rep := buildReport(results)
+	if err := writeReport(rep, *format, *out); err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "ttsload: %d requests, %d errors\n", rep.Total, rep.Errors)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// buildWav constructs a minimal canonical 16-bit PCM mono WAV file for tests.
+func buildWav(sampleRate int, numSamples int) []byte {
+	dataSize := numSamples * 2
+	buf := make([]byte, 44+dataSize)
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(36+dataSize))
+	copy(buf[8:12], "WAVE")
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], 1)
+	binary.LittleEndian.PutUint16(buf[22:24], 1) // mono
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(buf[28:32], uint32(sampleRate*2))
+	binary.LittleEndian.PutUint16(buf[32:34], 2)
+	binary.LittleEndian.PutUint16(buf[34:36], 16)
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(dataSize))
+	return buf
+}
+
+func TestParseWavAudioLen(t *testing.T) {
+	wav := buildWav(24000, 12000)
+	sampleRate, numSamples, ok := parseWavAudioLen(wav)
+	if !ok {
+		t.Fatal("parseWavAudioLen returned ok=false for a valid WAV")
+	}
+	if sampleRate != 24000 {
+		t.Errorf("sampleRate = %d, want 24000", sampleRate)
+	}
+	if numSamples != 12000 {
+		t.Errorf("numSamples = %d, want 12000", numSamples)
+	}
+}
+
+func TestParseWavAudioLen_ExtraChunkBeforeData(t *testing.T) {
+	base := buildWav(16000, 100)
+	// Splice in a fake "LIST" chunk between "fmt " and "data" to make sure
+	// the scanner doesn't assume the canonical 44-byte layout.
+	extra := []byte("LIST")
+	extra = append(extra, 4, 0, 0, 0)
+	extra = append(extra, []byte("JUNK")...)
+
+	wav := append(append(append([]byte{}, base[:36]...), extra...), base[36:]...)
+	sampleRate, numSamples, ok := parseWavAudioLen(wav)
+	if !ok {
+		t.Fatal("parseWavAudioLen returned ok=false with an extra chunk before data")
+	}
+	if sampleRate != 16000 || numSamples != 100 {
+		t.Errorf("got sampleRate=%d numSamples=%d, want 16000/100", sampleRate, numSamples)
+	}
+}
+
+func TestParseWavAudioLen_Invalid(t *testing.T) {
+	if _, _, ok := parseWavAudioLen([]byte("not a wav file")); ok {
+		t.Error("expected ok=false for non-WAV input")
+	}
+	if _, _, ok := parseWavAudioLen(nil); ok {
+		t.Error("expected ok=false for empty input")
+	}
+}
+
+func evenLines(n int, total time.Duration) []scriptLine {
+	lines := make([]scriptLine, n)
+	for i := range lines {
+		lines[i] = scriptLine{Offset: total * time.Duration(i) / time.Duration(n-1)}
+	}
+	return lines
+}
+
+func TestApplyProfile_Burst(t *testing.T) {
+	lines := applyProfile(evenLines(5, 10*time.Second), "burst")
+	for i, l := range lines {
+		if l.Offset != 0 {
+			t.Errorf("line %d offset = %v, want 0", i, l.Offset)
+		}
+	}
+}
+
+func TestApplyProfile_Steady(t *testing.T) {
+	original := evenLines(5, 10*time.Second)
+	lines := applyProfile(append([]scriptLine{}, original...), "steady")
+	for i := range lines {
+		if lines[i].Offset != original[i].Offset {
+			t.Errorf("steady line %d offset = %v, want unchanged %v", i, lines[i].Offset, original[i].Offset)
+		}
+	}
+}
+
+func TestApplyProfile_RampIncreasesDensity(t *testing.T) {
+	lines := applyProfile(evenLines(5, 10*time.Second), "ramp")
+
+	if lines[0].Offset != 0 || lines[len(lines)-1].Offset != 10*time.Second {
+		t.Fatalf("ramp should preserve the run's start and end, got first=%v last=%v", lines[0].Offset, lines[len(lines)-1].Offset)
+	}
+
+	firstGap := lines[1].Offset - lines[0].Offset
+	lastGap := lines[len(lines)-1].Offset - lines[len(lines)-2].Offset
+	if !(lastGap < firstGap) {
+		t.Errorf("ramp should pack later requests tighter than earlier ones: firstGap=%v lastGap=%v", firstGap, lastGap)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := parseFields(`speaker=af_sky lang=en text="Hello, world!"`)
+	if err != nil {
+		t.Fatalf("parseFields returned error: %v", err)
+	}
+	want := map[string]string{"speaker": "af_sky", "lang": "en", "text": "Hello, world!"}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("fields[%q] = %q, want %q", k, fields[k], v)
+		}
+	}
+}
+
+func TestParseFields_EscapedQuote(t *testing.T) {
+	fields, err := parseFields(`text="she said \"hi\""`)
+	if err != nil {
+		t.Fatalf("parseFields returned error: %v", err)
+	}
+	if want := `she said "hi"`; fields["text"] != want {
+		t.Errorf("fields[text] = %q, want %q", fields["text"], want)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import "math"
+
+// Used in place of the naive applyGain (which is kept only for the legacy
+// volume_gain path) for an ITU-R BS.1770 / EBU R128 integrated-loudness
+// measurement and a soft-knee limiter, so different speaker embeddings that
+// produce wildly different peak levels still land at a consistent perceived
+// loudness instead of just getting hard-clipped at +/-1.0.
+
+const (
+	lufsBlockSeconds       = 0.4  // EBU R128 gating block length
+	lufsOverlap            = 0.75 // 75% block overlap
+	lufsAbsoluteGate       = -70.0
+	lufsRelativeGateOffset = -10.0
+)
+
+// biquad is a direct-form II transposed biquad filter, used to build the
+// K-weighting pre-filter and RLB high-pass from BS.1770.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.z1
+	f.z1 = f.b1*x - f.a1*y + f.z2
+	f.z2 = f.b2*x - f.a2*y
+	return y
+}
+
+// newKWeightingFilters returns the two cascaded biquads BS.1770 defines for
+// K-weighting: a high-shelf pre-filter followed by an RLB high-pass.
+// Coefficients are the standard 48kHz ones; we accept the small error they
+// introduce at other sample rates rather than re-deriving per-rate filters.
+func newKWeightingFilters() []*biquad {
+	return []*biquad{
+		{b0: 1.53512485958697, b1: -2.69169618940638, b2: 1.19839281085285, a1: -1.69065929318241, a2: 0.73248077421585},
+		{b0: 1.0, b1: -2.0, b2: 1.0, a1: -1.99004745483398, a2: 0.99007225036621},
+	}
+}
+
+type lufsBlockMeasurement struct {
+	meanSq   float64
+	loudness float64
+}
+
+// measureIntegratedLUFS implements the BS.1770 gated-block integrated
+// loudness measurement: K-weight the signal, take mean-square over
+// overlapping 400ms blocks, then apply the absolute (-70 LUFS) and relative
+// (-10 dB) gates before averaging.
+func measureIntegratedLUFS(samples []float32, sampleRate int) float64 {
+	if len(samples) == 0 || sampleRate <= 0 {
+		return lufsAbsoluteGate
+	}
+
+	filters := newKWeightingFilters()
+	weighted := make([]float64, len(samples))
+	for i, s := range samples {
+		x := float64(s)
+		for _, f := range filters {
+			x = f.process(x)
+		}
+		weighted[i] = x
+	}
+
+	blockSize := int(lufsBlockSeconds * float64(sampleRate))
+	hop := int(float64(blockSize) * (1 - lufsOverlap))
+	if blockSize <= 0 || hop <= 0 || len(weighted) < blockSize {
+		blockSize = len(weighted)
+		hop = blockSize
+	}
+
+	var blocks []lufsBlockMeasurement
+	for start := 0; start+blockSize <= len(weighted); start += hop {
+		var sumSq float64
+		for _, v := range weighted[start : start+blockSize] {
+			sumSq += v * v
+		}
+		meanSq := sumSq / float64(blockSize)
+		if meanSq <= 0 {
+			continue
+		}
+		blocks = append(blocks, lufsBlockMeasurement{meanSq: meanSq, loudness: -0.691 + 10*math.Log10(meanSq)})
+	}
+	if len(blocks) == 0 {
+		return lufsAbsoluteGate
+	}
+
+	gateAbove := func(in []lufsBlockMeasurement, threshold float64) []lufsBlockMeasurement {
+		var out []lufsBlockMeasurement
+		for _, b := range in {
+			if b.loudness > threshold {
+				out = append(out, b)
+			}
+		}
+		return out
+	}
+	meanSqOf := func(in []lufsBlockMeasurement) float64 {
+		var sum float64
+		for _, b := range in {
+			sum += b.meanSq
+		}
+		return sum / float64(len(in))
+	}
+
+	absoluteGated := gateAbove(blocks, lufsAbsoluteGate)
+	if len(absoluteGated) == 0 {
+		return lufsAbsoluteGate
+	}
+
+	relativeGate := -0.691 + 10*math.Log10(meanSqOf(absoluteGated)) + lufsRelativeGateOffset
+	relativeGated := gateAbove(absoluteGated, relativeGate)
+	if len(relativeGated) == 0 {
+		relativeGated = absoluteGated
+	}
+
+	return -0.691 + 10*math.Log10(meanSqOf(relativeGated))
+}
+
+// softKneeLimit smoothly compresses samples above kneeStart toward ceiling
+// instead of hard-clipping at it, so normalization gain doesn't introduce
+// audible clipping artifacts on peaks.
+func softKneeLimit(v, ceiling, kneeStart float64) float64 {
+	av := math.Abs(v)
+	if av <= kneeStart {
+		return v
+	}
+	sign := 1.0
+	if v < 0 {
+		sign = -1.0
+	}
+	span := ceiling - kneeStart
+	over := av - kneeStart
+	return sign * (kneeStart + span*(1-math.Exp(-over/span)))
+}
+
+// normalizeLoudness measures the integrated loudness of samples and applies
+// the gain needed to bring it to targetLUFS, limiting sample peaks to
+// truePeakDBFS with a soft knee instead of the hard clipping applyGain does.
+// Peaks are taken per sample; inter-sample (oversampled) peaks are not measured.
+func normalizeLoudness(samples []float32, sampleRate int, targetLUFS, truePeakDBFS float32) []float32 {
+	measured := measureIntegratedLUFS(samples, sampleRate)
+	gain := math.Pow(10, (float64(targetLUFS)-measured)/20)
+
+	ceiling := math.Pow(10, float64(truePeakDBFS)/20)
+	kneeStart := ceiling * 0.9
+
+	out := make([]float32, len(samples))
+	for i, s := range samples {
+		out[i] = float32(softKneeLimit(float64(s)*gain, ceiling, kneeStart))
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func sineWave(amplitude float32, freqHz, sampleRate, seconds int) []float32 {
+	n := sampleRate * seconds
+	out := make([]float32, n)
+	for i := range out {
+		out[i] = amplitude * float32(math.Sin(2*math.Pi*float64(freqHz)*float64(i)/float64(sampleRate)))
+	}
+	return out
+}
+
+func TestMeasureIntegratedLUFS_EmptyOrSilent(t *testing.T) {
+	if got := measureIntegratedLUFS(nil, 48000); got != lufsAbsoluteGate {
+		t.Errorf("measureIntegratedLUFS(nil) = %v, want %v", got, lufsAbsoluteGate)
+	}
+
+	silence := make([]float32, 48000*2)
+	if got := measureIntegratedLUFS(silence, 48000); got != lufsAbsoluteGate {
+		t.Errorf("measureIntegratedLUFS(silence) = %v, want %v", got, lufsAbsoluteGate)
+	}
+}
+
+func TestMeasureIntegratedLUFS_LouderIsHigher(t *testing.T) {
+	quiet := sineWave(0.05, 1000, 48000, 2)
+	loud := sineWave(0.5, 1000, 48000, 2)
+
+	quietLUFS := measureIntegratedLUFS(quiet, 48000)
+	loudLUFS := measureIntegratedLUFS(loud, 48000)
+
+	if !(loudLUFS > quietLUFS) {
+		t.Errorf("expected loud signal LUFS (%v) > quiet signal LUFS (%v)", loudLUFS, quietLUFS)
+	}
+}
+
+func TestSoftKneeLimit(t *testing.T) {
+	ceiling := 0.9
+	kneeStart := ceiling * 0.9
+
+	if got := softKneeLimit(0.1, ceiling, kneeStart); got != 0.1 {
+		t.Errorf("softKneeLimit below knee should pass through unchanged, got %v", got)
+	}
+	if got := softKneeLimit(-0.1, ceiling, kneeStart); got != -0.1 {
+		t.Errorf("softKneeLimit below knee should pass through unchanged (negative), got %v", got)
+	}
+
+	for _, v := range []float64{1.0, 5.0, 1000.0} {
+		got := softKneeLimit(v, ceiling, kneeStart)
+		if got >= ceiling {
+			t.Errorf("softKneeLimit(%v) = %v, want strictly below ceiling %v", v, got, ceiling)
+		}
+		if got <= kneeStart {
+			t.Errorf("softKneeLimit(%v) = %v, want above kneeStart %v", v, got, kneeStart)
+		}
+	}
+
+	got := softKneeLimit(-5.0, ceiling, kneeStart)
+	if got <= -ceiling {
+		t.Errorf("softKneeLimit(-5.0) = %v, want strictly above -ceiling %v", got, -ceiling)
+	}
+}
+
+func TestNormalizeLoudnessRespectsCeiling(t *testing.T) {
+	samples := sineWave(0.9, 1000, 48000, 1)
+	out := normalizeLoudness(samples, 48000, 0, -1.0) // push toward 0 LUFS, a large boost
+	ceiling := math.Pow(10, -1.0/20)
+	for _, s := range out {
+		if math.Abs(float64(s)) > ceiling+1e-6 {
+			t.Fatalf("normalizeLoudness produced sample %v exceeding ceiling %v", s, ceiling)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import "testing"
+
+func TestParseProsodyRate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want float32
+	}{
+		{"empty defaults to 1.0", "", 1.0},
+		{"named medium", "medium", 1.0},
+		{"named slow", "slow", 0.75},
+		{"named fast", "fast", 1.25},
+		{"bare multiplier", "1.5", 1.5},
+		{"percentage", "150%", 1.5},
+		{"unparseable falls back to 1.0", "garbage", 1.0},
+		{"clamps above max", "100.0", maxProsodyRate},
+		{"clamps below min", "0.01", minProsodyRate},
+		{"clamps zero", "0", minProsodyRate},
+		{"clamps negative", "-5", minProsodyRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseProsodyRate(tt.in); got != tt.want {
+				t.Errorf("parseProsodyRate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBreakTime(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want float32
+	}{
+		{"milliseconds", "500ms", 0.5},
+		{"seconds", "2s", 2.0},
+		{"unparseable falls back to default", "garbage", SilenceDuration},
+		{"clamps huge value", "999999999s", maxBreakDuration},
+		{"clamps negative to zero", "-5s", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseBreakTime(tt.in); got != tt.want {
+				t.Errorf("parseBreakTime(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"single sentence", "Hello there.", []string{"Hello there."}},
+		{
+			"multiple sentences",
+			"Hello there. How are you? Fine!",
+			[]string{"Hello there.", "How are you?", "Fine!"},
+		},
+		{"no terminal punctuation", "Hello there", []string{"Hello there"}},
+		{
+			"trailing fragment without punctuation",
+			"First sentence. trailing fragment",
+			[]string{"First sentence.", "trailing fragment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitSentences(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitSentences(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("splitSentences(%q)[%d] = %q, want %q", tt.in, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSecretEnv is the environment variable holding the shared secret that
+// gates the mutating /voices endpoints.
+const AdminSecretEnv = "SUPERTONIC_ADMIN_SECRET"
+
+// AdminSecretHeader is the header clients must send to authenticate against
+// the mutating /voices endpoints.
+const AdminSecretHeader = "X-Admin-Secret"
+
+// voiceEntry pairs a loaded style with the bookkeeping needed for the
+// GET /voices listing.
+type voiceEntry struct {
+	style    *Style
+	loadedAt time.Time
+
+	// targetLUFS is an optional per-speaker loudness default, read directly
+	// from the style file's "target_lufs" key (Style itself doesn't carry
+	// this field). Zero means the speaker has no override and callers should
+	// fall back to DefaultTargetLUFS.
+	targetLUFS float32
+}
+
+// readStyleTargetLUFS looks for an optional top-level "target_lufs" key in a
+// voice style file so speakers can be leveled to a consistent perceived
+// loudness without every request having to pass target_lufs explicitly. Any
+// read or parse failure is treated as "no override" rather than an error,
+// since this is a best-effort convenience on top of loadFromFile's real
+// validation.
+func readStyleTargetLUFS(path string) float32 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var meta struct {
+		TargetLUFS float32 `json:"target_lufs"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return 0
+	}
+	return meta.TargetLUFS
+}
+
+// voiceStyleRegistry replaces the old init-time-only styleCache with a
+// concurrency-safe, mutable registry so speakers can be added, replaced, or
+// removed without restarting the server (and reloading every ONNX model).
+type voiceStyleRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]voiceEntry
+}
+
+var voiceRegistry = &voiceStyleRegistry{entries: make(map[string]voiceEntry)}
+
+func (r *voiceStyleRegistry) get(name string) (*Style, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if e, ok := r.entries[name]; ok {
+		return e.style, nil
+	}
+	return nil, fmt.Errorf("voice style not found: %s", name)
+}
+
+// targetLUFS returns the style-level loudness default registered for name,
+// or 0 if the speaker is unknown or has no override configured.
+func (r *voiceStyleRegistry) targetLUFS(name string) float32 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.entries[name].targetLUFS
+}
+
+func (r *voiceStyleRegistry) delete(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[name]; !ok {
+		return false
+	}
+	delete(r.entries, name)
+	return true
+}
+
+// list returns a metadata snapshot sorted by name for the GET /voices response.
+func (r *voiceStyleRegistry) list() []gin.H {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]gin.H, 0, len(r.entries))
+	for name, e := range r.entries {
+		out = append(out, gin.H{
+			"name":      name,
+			"loaded_at": e.loadedAt.Format(time.RFC3339),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i]["name"].(string) < out[j]["name"].(string) })
+	return out
+}
+
+// loadFromFile loads a single speaker's style JSON file from VoiceStyleDir
+// and registers it under the speaker name derived from the filename.
+func (r *voiceStyleRegistry) loadFromFile(path string) error {
+	speakerName := strings.TrimSuffix(filepath.Base(path), ".json")
+	style, err := LoadVoiceStyle([]string{path}, false)
+	if err != nil {
+		return fmt.Errorf("failed to load voice style %s: %w", speakerName, err)
+	}
+	r.mu.Lock()
+	r.entries[speakerName] = voiceEntry{style: style, loadedAt: time.Now(), targetLUFS: readStyleTargetLUFS(path)}
+	r.mu.Unlock()
+	return nil
+}
+
+// reload rescans VoiceStyleDir from scratch, replacing the current registry
+// contents with whatever is found on disk.
+func (r *voiceStyleRegistry) reload() error {
+	files, err := os.ReadDir(VoiceStyleDir)
+	if err != nil {
+		return fmt.Errorf("failed to read voice style directory: %w", err)
+	}
+
+	fresh := make(map[string]voiceEntry)
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(VoiceStyleDir, file.Name())
+		style, err := LoadVoiceStyle([]string{path}, false)
+		if err != nil {
+			log.Printf("Warning: failed to load voice style %s: %v", file.Name(), err)
+			continue
+		}
+		fresh[strings.TrimSuffix(file.Name(), ".json")] = voiceEntry{style: style, loadedAt: time.Now(), targetLUFS: readStyleTargetLUFS(path)}
+	}
+
+	r.mu.Lock()
+	r.entries = fresh
+	r.mu.Unlock()
+
+	log.Printf("Loaded %d voice styles into registry", len(fresh))
+	return nil
+}
+
+// watchVoiceStyleDir starts an fsnotify watcher that live-registers any
+// *.json file dropped into VoiceStyleDir without requiring a restart.
+func watchVoiceStyleDir() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create voice style watcher: %w", err)
+	}
+	if err := watcher.Add(VoiceStyleDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch %s: %w", VoiceStyleDir, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) != ".json" {
+					continue
+				}
+				if event.Op&(fsnotify.Create|fsnotify.Write) != 0 {
+					if err := voiceRegistry.loadFromFile(event.Name); err != nil {
+						log.Printf("Voice style watcher: %v", err)
+					} else {
+						log.Printf("Voice style watcher: registered %s", filepath.Base(event.Name))
+					}
+				} else if event.Op&fsnotify.Remove != 0 {
+					name := strings.TrimSuffix(filepath.Base(event.Name), ".json")
+					if voiceRegistry.delete(name) {
+						log.Printf("Voice style watcher: evicted %s", name)
+					}
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Voice style watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// generateAdminSecret produces a random hex secret for AdminSecretEnv when
+// the operator hasn't configured one, so the mutating /voices endpoints fail
+// closed by default instead of being left wide open.
+func generateAdminSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate admin secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// requireAdminSecret gates mutating /voices endpoints behind a shared secret
+// configured via AdminSecretEnv. main() guarantees the env var is always set
+// (generating a random one at startup if the operator didn't configure one),
+// so an empty value here means something cleared it after startup; that
+// fails closed too rather than falling back to an open admin API.
+func requireAdminSecret() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := os.Getenv(AdminSecretEnv)
+		if secret == "" || c.GetHeader(AdminSecretHeader) != secret {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid " + AdminSecretHeader})
+			return
+		}
+		c.Next()
+	}
+}
+
+// listVoicesHandler handles GET /voices.
+func listVoicesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"voices": voiceRegistry.list()})
+}
+
+// reloadVoicesHandler handles POST /voices/reload.
+func reloadVoicesHandler(c *gin.Context) {
+	if err := voiceRegistry.reload(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"voices": voiceRegistry.list()})
+}
+
+// putVoiceHandler handles PUT /voices/:name: the request body is the style
+// JSON, which is validated before it's persisted to VoiceStyleDir (so it
+// survives a restart or a later full reload) and registered immediately.
+func putVoiceHandler(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing voice name"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("failed to read request body: %v", err)})
+		return
+	}
+
+	// Validate against a scratch file outside VoiceStyleDir before touching
+	// it (and before the fsnotify watcher can see it), so a rejected PUT
+	// never leaves a bad file behind to be rediscovered (and re-warned-about)
+	// on the next reload or restart.
+	scratch, err := os.CreateTemp("", "put-voice-"+name+"-*.json")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to stage style file: %v", err)})
+		return
+	}
+	scratchPath := scratch.Name()
+	defer os.Remove(scratchPath)
+	if _, err := scratch.Write(body); err != nil {
+		scratch.Close()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to stage style file: %v", err)})
+		return
+	}
+	scratch.Close()
+
+	if _, err := LoadVoiceStyle([]string{scratchPath}, false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid voice style: %v", err)})
+		return
+	}
+
+	path := filepath.Join(VoiceStyleDir, name+".json")
+	if err := os.WriteFile(path, body, 0644); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to write style file: %v", err)})
+		return
+	}
+
+	if err := voiceRegistry.loadFromFile(path); err != nil {
+		os.Remove(path)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"name": name, "status": "registered"})
+}
+
+// deleteVoiceHandler handles DELETE /voices/:name. It evicts the speaker
+// from the in-memory registry AND removes its style file from
+// VoiceStyleDir, so a later /voices/reload or server restart doesn't
+// silently resurrect a voice an operator just deleted.
+func deleteVoiceHandler(c *gin.Context) {
+	name := c.Param("name")
+	if !voiceRegistry.delete(name) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("voice style not found: %s", name)})
+		return
+	}
+
+	path := filepath.Join(VoiceStyleDir, name+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"name":   name,
+			"status": "evicted",
+			"error":  fmt.Sprintf("evicted from registry but failed to remove style file: %v", err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"name": name, "status": "deleted"})
+}
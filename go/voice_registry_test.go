@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestRegistry() *voiceStyleRegistry {
+	return &voiceStyleRegistry{entries: make(map[string]voiceEntry)}
+}
+
+func TestVoiceStyleRegistry_GetMissing(t *testing.T) {
+	r := newTestRegistry()
+	if _, err := r.get("nope"); err == nil {
+		t.Error("expected an error for a speaker that was never registered")
+	}
+}
+
+func TestVoiceStyleRegistry_GetAfterDirectInsert(t *testing.T) {
+	r := newTestRegistry()
+	r.entries["af_sky"] = voiceEntry{loadedAt: time.Now(), targetLUFS: -18}
+
+	style, err := r.get("af_sky")
+	if err != nil {
+		t.Fatalf("get returned error: %v", err)
+	}
+	if style != nil {
+		t.Errorf("style = %v, want nil (test entry has no style)", style)
+	}
+	if got := r.targetLUFS("af_sky"); got != -18 {
+		t.Errorf("targetLUFS(af_sky) = %v, want -18", got)
+	}
+}
+
+func TestVoiceStyleRegistry_TargetLUFSMissingSpeakerIsZero(t *testing.T) {
+	r := newTestRegistry()
+	if got := r.targetLUFS("nope"); got != 0 {
+		t.Errorf("targetLUFS(nope) = %v, want 0", got)
+	}
+}
+
+func TestVoiceStyleRegistry_Delete(t *testing.T) {
+	r := newTestRegistry()
+	r.entries["af_sky"] = voiceEntry{loadedAt: time.Now()}
+
+	if !r.delete("af_sky") {
+		t.Fatal("delete should report true for a registered speaker")
+	}
+	if r.delete("af_sky") {
+		t.Error("delete should report false the second time")
+	}
+	if _, err := r.get("af_sky"); err == nil {
+		t.Error("speaker should be gone after delete")
+	}
+}
+
+func TestVoiceStyleRegistry_List(t *testing.T) {
+	r := newTestRegistry()
+	r.entries["af_sky"] = voiceEntry{loadedAt: time.Now()}
+	r.entries["af_bella"] = voiceEntry{loadedAt: time.Now()}
+
+	list := r.list()
+	if len(list) != 2 {
+		t.Fatalf("len(list) = %d, want 2", len(list))
+	}
+	seen := map[string]bool{}
+	for _, entry := range list {
+		seen[entry["name"].(string)] = true
+	}
+	if !seen["af_sky"] || !seen["af_bella"] {
+		t.Errorf("list %v missing an expected speaker", list)
+	}
+}
+
+func TestReadStyleTargetLUFS(t *testing.T) {
+	dir := t.TempDir()
+
+	withField := filepath.Join(dir, "with_field.json")
+	if err := os.WriteFile(withField, []byte(`{"target_lufs": -14.5}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readStyleTargetLUFS(withField); got != -14.5 {
+		t.Errorf("readStyleTargetLUFS(with_field) = %v, want -14.5", got)
+	}
+
+	withoutField := filepath.Join(dir, "without_field.json")
+	if err := os.WriteFile(withoutField, []byte(`{"some_other_key": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readStyleTargetLUFS(withoutField); got != 0 {
+		t.Errorf("readStyleTargetLUFS(without_field) = %v, want 0", got)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readStyleTargetLUFS(invalid); got != 0 {
+		t.Errorf("readStyleTargetLUFS(invalid) = %v, want 0", got)
+	}
+
+	if got := readStyleTargetLUFS(filepath.Join(dir, "missing.json")); got != 0 {
+		t.Errorf("readStyleTargetLUFS(missing) = %v, want 0", got)
+	}
+}
+
+func TestGenerateAdminSecret(t *testing.T) {
+	a, err := generateAdminSecret()
+	if err != nil {
+		t.Fatalf("generateAdminSecret returned error: %v", err)
+	}
+	b, err := generateAdminSecret()
+	if err != nil {
+		t.Fatalf("generateAdminSecret returned error: %v", err)
+	}
+	if a == "" {
+		t.Error("generateAdminSecret returned an empty string")
+	}
+	if a == b {
+		t.Error("two calls to generateAdminSecret returned the same secret")
+	}
+}